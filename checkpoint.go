@@ -0,0 +1,65 @@
+package parse
+
+import "github.com/zalgonoise/lex"
+
+// Cursor is implemented by a lexer that can report and rewind its own read position, such
+// as the cur.Cursor embedded in github.com/zalgonoise/lex's Lex. A Tree uses it, when
+// available, so Checkpoint / Restore can rewind the lexer itself alongside the Tree's
+// lookahead buffer and node pointer, allowing true arbitrary-length backtracking.
+//
+// If the Tree's lexer does not implement Cursor, Restore can only replay Items still held
+// in the lookahead buffer (see WithLookahead); Items pulled from the lexer and since evicted
+// from the buffer are lost, and Restore silently falls back to whatever the buffer retains.
+type Cursor[T any] interface {
+	// Pos returns the lexer's current cursor position.
+	Pos() int
+	// Idx moves the lexer's cursor to position idx, returning the input value at that index.
+	Idx(idx int) T
+}
+
+// Checkpoint is a snapshot of a Tree's position -- its current Node, buffered lookahead
+// Items, and (if the underlying lexer implements Cursor) the lexer's read position --
+// taken by Tree.Checkpoint and restored by Tree.Restore.
+//
+// This lets a ParseFn attempt one alternative and, on failure, rewind the Tree and try
+// another. Rewinding beyond the lookahead buffer's capacity requires a lexer that
+// implements Cursor; see Cursor's doc comment for the fallback behavior when it does not.
+type Checkpoint[C comparable, T any] struct {
+	node   *Node[C, T]
+	ring   []lex.Item[C, T]
+	head   int
+	count  int
+	lexPos int
+	hasLex bool
+}
+
+// Checkpoint snapshots the Tree's current Node, its buffered lookahead Items, and (if the
+// underlying lexer implements Cursor) the lexer's read position.
+func (t *Tree[C, T]) Checkpoint() Checkpoint[C, T] {
+	cp := Checkpoint[C, T]{
+		node:  t.node,
+		ring:  append([]lex.Item[C, T](nil), t.ring...),
+		head:  t.head,
+		count: t.count,
+	}
+	if c, ok := t.lex.(Cursor[T]); ok {
+		cp.lexPos = c.Pos()
+		cp.hasLex = true
+	}
+	return cp
+}
+
+// Restore rewinds the Tree to the position captured in cp: its current Node, its buffered
+// lookahead Items, and (if the underlying lexer implements Cursor) the lexer's read
+// position.
+func (t *Tree[C, T]) Restore(cp Checkpoint[C, T]) {
+	t.node = cp.node
+	t.ring = append([]lex.Item[C, T](nil), cp.ring...)
+	t.head = cp.head
+	t.count = cp.count
+	if cp.hasLex {
+		if c, ok := t.lex.(Cursor[T]); ok {
+			c.Idx(cp.lexPos)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package parse
+
+import "github.com/zalgonoise/lex"
+
+// Copy returns a deep copy of the Node and its subtree, with Edges re-parented to their
+// copies. The copy has no Parent; it is the caller's responsibility to attach it, if
+// needed.
+func (n *Node[C, T]) Copy() *Node[C, T] {
+	return copyNode(n, map[*Node[C, T]]*Node[C, T]{})
+}
+
+func copyNode[C comparable, T any](n *Node[C, T], mapping map[*Node[C, T]]*Node[C, T]) *Node[C, T] {
+	cp := &Node[C, T]{
+		Item:  n.Item,
+		Edges: make([]*Node[C, T], len(n.Edges)),
+	}
+	mapping[n] = cp
+
+	for i, edge := range n.Edges {
+		child := copyNode(edge, mapping)
+		child.Parent = cp
+		cp.Edges[i] = child
+	}
+	return cp
+}
+
+// Copy returns a deep copy of the Tree: its Node graph is fully duplicated, with Edges
+// re-parented to their copies, and its current position mirrors the original Tree's. The
+// lexer, ParseFn, Mode and trace writer are shared with the original, while the lookahead
+// buffer is duplicated and the BackupSlots are reset (not carried over).
+//
+// This allows a ParseFn to mutate a copy of the Tree without disturbing the original.
+func (t *Tree[C, T]) Copy() *Tree[C, T] {
+	mapping := map[*Node[C, T]]*Node[C, T]{}
+	root := copyNode(t.Root, mapping)
+
+	node := mapping[t.node]
+	if node == nil {
+		node = root
+	}
+
+	return &Tree[C, T]{
+		Root:       root,
+		node:       node,
+		lex:        t.lex,
+		last:       t.last,
+		backup:     map[BackupSlot]*Node[C, T]{},
+		parseFn:    t.parseFn,
+		mode:       t.mode,
+		ring:       append([]lex.Item[C, T](nil), t.ring...),
+		head:       t.head,
+		count:      t.count,
+		trace:      t.trace,
+		traceDepth: t.traceDepth,
+	}
+}
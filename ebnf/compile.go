@@ -0,0 +1,354 @@
+package ebnf
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zalgonoise/parse"
+)
+
+// compiler holds the intermediate state built while validating and compiling a Grammar: the
+// FIRST set and nullability computed for each Production, keyed by Production name.
+type compiler[C comparable, T any] struct {
+	grammar  Grammar[C]
+	firsts   map[string]map[C]bool
+	nullable map[string]bool
+}
+
+// Compile validates Grammar g -- reporting left recursion (ErrLeftRecursion) and FIRST-set
+// conflicts (ErrFirstSetConflict) at compile time -- and returns a ParseFn that recursively
+// descends through g's Productions starting at g.Root. The returned ParseFn, together with
+// g.RootToken, can be dropped directly into parse.Run.
+func Compile[C comparable, T any](g Grammar[C]) (parse.ParseFn[C, T], error) {
+	c := &compiler[C, T]{
+		grammar:  g,
+		firsts:   map[string]map[C]bool{},
+		nullable: map[string]bool{},
+	}
+
+	if _, ok := g.Productions[g.Root]; !ok {
+		return nil, fmt.Errorf("%w: root %q", ErrUnknownProduction, g.Root)
+	}
+
+	c.computeNullable()
+
+	if err := c.checkLeftRecursion(); err != nil {
+		return nil, err
+	}
+
+	c.computeFirsts()
+
+	if err := c.checkConflicts(); err != nil {
+		return nil, err
+	}
+
+	return c.rootParseFn(), nil
+}
+
+// Firsts returns the computed FIRST set for the named Production in Grammar g, or an error
+// if g fails to compile. This lets callers inspect the grammar the same way Compile does,
+// without generating a ParseFn.
+func Firsts[C comparable](g Grammar[C], name string) ([]C, error) {
+	c := &compiler[C, any]{grammar: g, firsts: map[string]map[C]bool{}, nullable: map[string]bool{}}
+	if _, ok := g.Productions[g.Root]; !ok {
+		return nil, fmt.Errorf("%w: root %q", ErrUnknownProduction, g.Root)
+	}
+	c.computeNullable()
+	if err := c.checkLeftRecursion(); err != nil {
+		return nil, err
+	}
+	c.computeFirsts()
+	if err := c.checkConflicts(); err != nil {
+		return nil, err
+	}
+
+	set, ok := c.firsts[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownProduction, name)
+	}
+
+	tokens := make([]C, 0, len(set))
+	for tok := range set {
+		tokens = append(tokens, tok)
+	}
+	return tokens, nil
+}
+
+// checkLeftRecursion walks every Production, reporting ErrLeftRecursion if a Production can
+// reach itself through the leading (leftmost) Expr of a Ref chain.
+func (c *compiler[C, T]) checkLeftRecursion() error {
+	for name := range c.grammar.Productions {
+		if err := c.visitLeading(name, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *compiler[C, T]) visitLeading(name string, stack []string) error {
+	for _, seen := range stack {
+		if seen == name {
+			return fmt.Errorf("%w: %s", ErrLeftRecursion, strings.Join(append(stack, name), " -> "))
+		}
+	}
+
+	prod, ok := c.grammar.Productions[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownProduction, name)
+	}
+
+	return c.visitLeadingExpr(prod.Expr, append(stack, name))
+}
+
+func (c *compiler[C, T]) visitLeadingExpr(e Expr[C], stack []string) error {
+	switch v := e.(type) {
+	case Ref[C]:
+		return c.visitLeading(v.Name, stack)
+	case Seq[C]:
+		for _, sub := range v.Exprs {
+			if err := c.visitLeadingExpr(sub, stack); err != nil {
+				return err
+			}
+			if !c.exprNullable(sub) {
+				break
+			}
+		}
+		return nil
+	case Alt[C]:
+		for _, alt := range v.Exprs {
+			if err := c.visitLeadingExpr(alt, stack); err != nil {
+				return err
+			}
+		}
+		return nil
+	case Opt[C]:
+		return c.visitLeadingExpr(v.Expr, stack)
+	case Rep[C]:
+		return c.visitLeadingExpr(v.Expr, stack)
+	default:
+		return nil
+	}
+}
+
+// computeNullable iterates every Production to a fixpoint, recording which ones can match
+// the empty string. This is needed before collectFirst can correctly cascade past a
+// leading Seq element (e.g. an Opt or Rep) that may consume zero tokens.
+func (c *compiler[C, T]) computeNullable() {
+	changed := true
+	for changed {
+		changed = false
+		for name, prod := range c.grammar.Productions {
+			if c.nullable[name] {
+				continue
+			}
+			if c.exprNullable(prod.Expr) {
+				c.nullable[name] = true
+				changed = true
+			}
+		}
+	}
+}
+
+func (c *compiler[C, T]) exprNullable(e Expr[C]) bool {
+	switch v := e.(type) {
+	case Terminal[C]:
+		return false
+	case Ref[C]:
+		return c.nullable[v.Name]
+	case Seq[C]:
+		for _, sub := range v.Exprs {
+			if !c.exprNullable(sub) {
+				return false
+			}
+		}
+		return true
+	case Alt[C]:
+		for _, alt := range v.Exprs {
+			if c.exprNullable(alt) {
+				return true
+			}
+		}
+		return false
+	case Opt[C]:
+		return true
+	case Rep[C]:
+		return true
+	default:
+		return false
+	}
+}
+
+// computeFirsts iterates every Production to a fixpoint, accumulating the set of tokens
+// that can appear first when matching it.
+func (c *compiler[C, T]) computeFirsts() {
+	changed := true
+	for changed {
+		changed = false
+		for name, prod := range c.grammar.Productions {
+			set := c.firsts[name]
+			if set == nil {
+				set = map[C]bool{}
+				c.firsts[name] = set
+			}
+			before := len(set)
+			c.collectFirst(prod.Expr, set)
+			if len(set) != before {
+				changed = true
+			}
+		}
+	}
+}
+
+func (c *compiler[C, T]) collectFirst(e Expr[C], set map[C]bool) {
+	switch v := e.(type) {
+	case Terminal[C]:
+		set[v.Token] = true
+	case Ref[C]:
+		for tok := range c.firsts[v.Name] {
+			set[tok] = true
+		}
+	case Seq[C]:
+		for _, sub := range v.Exprs {
+			c.collectFirst(sub, set)
+			if !c.exprNullable(sub) {
+				break
+			}
+		}
+	case Alt[C]:
+		for _, alt := range v.Exprs {
+			c.collectFirst(alt, set)
+		}
+	case Opt[C]:
+		c.collectFirst(v.Expr, set)
+	case Rep[C]:
+		c.collectFirst(v.Expr, set)
+	}
+}
+
+// checkConflicts reports ErrFirstSetConflict if any Alt in the Grammar has alternatives
+// whose FIRST sets overlap, which a predictive parser cannot disambiguate by peeking a
+// single token.
+func (c *compiler[C, T]) checkConflicts() error {
+	for name, prod := range c.grammar.Productions {
+		if err := c.checkExprConflicts(name, prod.Expr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *compiler[C, T]) checkExprConflicts(name string, e Expr[C]) error {
+	switch v := e.(type) {
+	case Alt[C]:
+		seen := map[C]bool{}
+		for _, alt := range v.Exprs {
+			first := map[C]bool{}
+			c.collectFirst(alt, first)
+			for tok := range first {
+				if seen[tok] {
+					return fmt.Errorf("%w: production %q, token %v", ErrFirstSetConflict, name, tok)
+				}
+				seen[tok] = true
+			}
+			if err := c.checkExprConflicts(name, alt); err != nil {
+				return err
+			}
+		}
+		return nil
+	case Seq[C]:
+		for _, sub := range v.Exprs {
+			if err := c.checkExprConflicts(name, sub); err != nil {
+				return err
+			}
+		}
+		return nil
+	case Opt[C]:
+		return c.checkExprConflicts(name, v.Expr)
+	case Rep[C]:
+		return c.checkExprConflicts(name, v.Expr)
+	default:
+		return nil
+	}
+}
+
+// rootParseFn returns the ParseFn that drives the predictive parser from the Grammar's
+// Root Production. It always returns nil, since matching the Root Production consumes the
+// entire grammar in one pass; failures are surfaced via Tree.Errorf.
+func (c *compiler[C, T]) rootParseFn() parse.ParseFn[C, T] {
+	return func(t *parse.Tree[C, T]) parse.ParseFn[C, T] {
+		if err := c.parseProduction(t, c.grammar.Root); err != nil {
+			t.Errorf("%w", err)
+		}
+		return nil
+	}
+}
+
+// parseProduction nests a Node for the named Production, matches its Expr against the
+// Tree, and returns to the parent Node once the Production completes.
+func (c *compiler[C, T]) parseProduction(t *parse.Tree[C, T], name string) error {
+	prod, ok := c.grammar.Productions[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownProduction, name)
+	}
+
+	t.Node(t.Peek())
+	if err := c.matchExpr(t, prod.Expr); err != nil {
+		return err
+	}
+	return t.Set(t.Parent())
+}
+
+func (c *compiler[C, T]) matchExpr(t *parse.Tree[C, T], e Expr[C]) error {
+	switch v := e.(type) {
+	case Terminal[C]:
+		next := t.Peek()
+		if next.Type != v.Token {
+			return fmt.Errorf("%w: want %v, got %v", ErrUnexpectedToken, v.Token, next.Type)
+		}
+		t.Next()
+		return nil
+
+	case Ref[C]:
+		return c.parseProduction(t, v.Name)
+
+	case Seq[C]:
+		for _, sub := range v.Exprs {
+			if err := c.matchExpr(t, sub); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case Alt[C]:
+		next := t.Peek()
+		for _, alt := range v.Exprs {
+			first := map[C]bool{}
+			c.collectFirst(alt, first)
+			if first[next.Type] {
+				return c.matchExpr(t, alt)
+			}
+		}
+		return fmt.Errorf("%w: %v", ErrUnexpectedToken, next.Type)
+
+	case Opt[C]:
+		first := map[C]bool{}
+		c.collectFirst(v.Expr, first)
+		if first[t.Peek().Type] {
+			return c.matchExpr(t, v.Expr)
+		}
+		return nil
+
+	case Rep[C]:
+		first := map[C]bool{}
+		c.collectFirst(v.Expr, first)
+		for first[t.Peek().Type] {
+			if err := c.matchExpr(t, v.Expr); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("%w: %T", ErrUnexpectedToken, e)
+	}
+}
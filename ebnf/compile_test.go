@@ -0,0 +1,147 @@
+package ebnf
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/zalgonoise/lex"
+	"github.com/zalgonoise/parse"
+)
+
+type token int
+
+const (
+	tokenNum token = iota
+	tokenPlus
+	tokenEOF
+)
+
+type stubLexer struct {
+	items []lex.Item[token, string]
+	idx   int
+}
+
+func (s *stubLexer) NextItem() lex.Item[token, string] {
+	if s.idx >= len(s.items) {
+		return s.items[len(s.items)-1]
+	}
+	item := s.items[s.idx]
+	s.idx++
+	return item
+}
+
+func sumGrammar() Grammar[token] {
+	return Grammar[token]{
+		Root:      "expr",
+		RootToken: tokenEOF,
+		Productions: map[string]Production[token]{
+			"expr": {
+				Name: "expr",
+				Expr: Seq[token]{Exprs: []Expr[token]{
+					Ref[token]{Name: "term"},
+					Rep[token]{Expr: Seq[token]{Exprs: []Expr[token]{
+						Terminal[token]{Token: tokenPlus},
+						Ref[token]{Name: "term"},
+					}}},
+				}},
+			},
+			"term": {
+				Name: "term",
+				Expr: Terminal[token]{Token: tokenNum},
+			},
+		},
+	}
+}
+
+func TestCompile_ParsesGrammar(t *testing.T) {
+	initParse, err := Compile[token, string](sumGrammar())
+	if err != nil {
+		t.Fatalf("unexpected error compiling grammar: %v", err)
+	}
+
+	l := &stubLexer{items: []lex.Item[token, string]{
+		lex.NewItem(0, tokenNum, "1"),
+		lex.NewItem(1, tokenPlus, "+"),
+		lex.NewItem(2, tokenNum, "2"),
+		lex.NewItem[token, string](3, tokenEOF),
+	}}
+
+	tree := parse.New[token, string](l, initParse, tokenEOF)
+	if err := tree.Parse(); err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+
+	if len(tree.List()) != 1 {
+		t.Fatalf("expected a single top-level expr node, got %d", len(tree.List()))
+	}
+}
+
+// TestCompile_NullableLeadingElement exercises a grammar whose leading Seq element can
+// match zero tokens (Opt[A]), so the production's FIRST set must also include B's token.
+func TestCompile_NullableLeadingElement(t *testing.T) {
+	g := Grammar[token]{
+		Root:      "expr",
+		RootToken: tokenEOF,
+		Productions: map[string]Production[token]{
+			"expr": {
+				Name: "expr",
+				Expr: Alt[token]{Exprs: []Expr[token]{
+					Seq[token]{Exprs: []Expr[token]{
+						Opt[token]{Expr: Terminal[token]{Token: tokenNum}},
+						Terminal[token]{Token: tokenPlus},
+					}},
+					Terminal[token]{Token: tokenEOF},
+				}},
+			},
+		},
+	}
+
+	initParse, err := Compile[token, string](g)
+	if err != nil {
+		t.Fatalf("unexpected error compiling grammar: %v", err)
+	}
+
+	l := &stubLexer{items: []lex.Item[token, string]{
+		lex.NewItem(0, tokenPlus, "+"),
+		lex.NewItem[token, string](1, tokenEOF),
+	}}
+
+	tree := parse.New[token, string](l, initParse, tokenEOF)
+	if err := tree.Parse(); err != nil {
+		t.Fatalf("unexpected parse error: %v", err)
+	}
+}
+
+func TestCompile_DetectsLeftRecursion(t *testing.T) {
+	g := Grammar[token]{
+		Root: "a",
+		Productions: map[string]Production[token]{
+			"a": {Name: "a", Expr: Ref[token]{Name: "a"}},
+		},
+	}
+
+	_, err := Compile[token, string](g)
+	if !errors.Is(err, ErrLeftRecursion) {
+		t.Fatalf("expected ErrLeftRecursion, got %v", err)
+	}
+}
+
+func TestCompile_DetectsFirstSetConflict(t *testing.T) {
+	g := Grammar[token]{
+		Root: "expr",
+		Productions: map[string]Production[token]{
+			"expr": {
+				Name: "expr",
+				Expr: Alt[token]{Exprs: []Expr[token]{
+					Terminal[token]{Token: tokenNum},
+					Terminal[token]{Token: tokenNum},
+				}},
+			},
+		},
+	}
+
+	_, err := Compile[token, string](g)
+	if !errors.Is(err, ErrFirstSetConflict) {
+		t.Fatalf("expected ErrFirstSetConflict, got %v", err)
+	}
+}
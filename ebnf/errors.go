@@ -0,0 +1,18 @@
+package ebnf
+
+import "errors"
+
+var (
+	// ErrUnknownProduction is a preset error for a Ref or Grammar.Root naming a Production
+	// that is not registered in the Grammar
+	ErrUnknownProduction = errors.New("unknown production")
+	// ErrLeftRecursion is a preset error for a Production that is left-recursive, directly
+	// or through a chain of Refs, which a predictive parser cannot resolve
+	ErrLeftRecursion = errors.New("left recursion is not allowed")
+	// ErrFirstSetConflict is a preset error for an Alt whose alternatives share a token in
+	// their FIRST sets, making the alternative ambiguous to a predictive parser
+	ErrFirstSetConflict = errors.New("conflicting FIRST sets in alternation")
+	// ErrUnexpectedToken is a preset error for when the next token does not match what a
+	// Terminal, Alt or Seq expects while matching a Production
+	ErrUnexpectedToken = errors.New("unexpected token")
+)
@@ -0,0 +1,72 @@
+// Package ebnf builds ParseFns from an EBNF-like grammar description, so that callers do
+// not need to hand-write a recursive-descent ParseFn for every grammar they support.
+package ebnf
+
+// Grammar is a compiled-ready description of a context-free grammar, as a set of named
+// Productions keyed by their Name. RootToken is the token type the resulting Tree's root
+// Node is created with, and Root names the Production the generated ParseFn starts from.
+type Grammar[C comparable] struct {
+	Productions map[string]Production[C]
+	Root        string
+	RootToken   C
+}
+
+// Production is a single named grammar rule. It is matched into a Node in the resulting
+// Tree, whose Item is the token peeked when matching starts. Productions it references
+// are nested as that Node's Edges; Terminals are consumed without creating Nodes.
+type Production[C comparable] struct {
+	Name string
+	Expr Expr[C]
+}
+
+// Expr is a single element of a Production's right-hand side: a terminal token, a
+// reference to another Production, or a composition of other Exprs (sequence,
+// alternation, optional, repetition).
+type Expr[C comparable] interface {
+	isExpr()
+}
+
+// Terminal matches a single token of type C, identified by the user's own token constants
+// (e.g. TokenLBrace, TokenIdent).
+type Terminal[C comparable] struct {
+	Token C
+}
+
+func (Terminal[C]) isExpr() {}
+
+// Ref refers to another named Production, nesting it as a child Node when matched.
+type Ref[C comparable] struct {
+	Name string
+}
+
+func (Ref[C]) isExpr() {}
+
+// Seq matches every Expr in Exprs, in order.
+type Seq[C comparable] struct {
+	Exprs []Expr[C]
+}
+
+func (Seq[C]) isExpr() {}
+
+// Alt matches the first alternative in Exprs whose FIRST set contains the next token.
+type Alt[C comparable] struct {
+	Exprs []Expr[C]
+}
+
+func (Alt[C]) isExpr() {}
+
+// Opt matches Expr zero or one times, depending on whether the next token is in its
+// FIRST set.
+type Opt[C comparable] struct {
+	Expr Expr[C]
+}
+
+func (Opt[C]) isExpr() {}
+
+// Rep matches Expr zero or more times, stopping once the next token is no longer in its
+// FIRST set.
+type Rep[C comparable] struct {
+	Expr Expr[C]
+}
+
+func (Rep[C]) isExpr() {}
@@ -0,0 +1,25 @@
+package parse
+
+import (
+	"fmt"
+
+	"github.com/zalgonoise/lex"
+)
+
+// Error is returned by Tree.Parse when a ParseFn aborts the parse via Tree.Errorf or
+// Tree.Error. It carries the last lex.Item consumed from the lexer before the failure,
+// so callers can report position information alongside the underlying Cause.
+type Error[C comparable, T any] struct {
+	Item  lex.Item[C, T]
+	Cause error
+}
+
+// Error implements the error interface.
+func (e *Error[C, T]) Error() string {
+	return fmt.Sprintf("parse error at %v: %v", e.Item, e.Cause)
+}
+
+// Unwrap returns the Error's Cause, allowing it to be inspected with errors.Is / errors.As.
+func (e *Error[C, T]) Unwrap() error {
+	return e.Cause
+}
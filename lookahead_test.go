@@ -0,0 +1,98 @@
+package parse
+
+import (
+	"testing"
+
+	"github.com/zalgonoise/lex"
+)
+
+const tokenWordB stubToken = 2
+
+func TestTree_PeekN(t *testing.T) {
+	l := &stubLexer[stubToken, string]{
+		items: []lex.Item[stubToken, string]{
+			lex.NewItem(0, tokenWord, "a"),
+			lex.NewItem(1, tokenWordB, "b"),
+			lex.NewItem[stubToken, string](2, tokenEOF),
+		},
+	}
+
+	tree := New[stubToken, string](l, nil, tokenEOF, WithLookahead[stubToken, string](3))
+
+	if got := tree.PeekN(2); got.Type != tokenWordB {
+		t.Fatalf("expected second lookahead item to be %v, got %v", tokenWordB, got.Type)
+	}
+	if got := tree.Next(); got.Type != tokenWord {
+		t.Fatalf("expected Next to still return the first item, got %v", got.Type)
+	}
+}
+
+type cpToken int
+
+const (
+	cpWord cpToken = iota
+	cpEOF
+)
+
+// cpInitState emits each rune of the input as its own cpWord Item, then a trailing cpEOF.
+func cpInitState(l lex.Lexer[cpToken, rune]) lex.StateFn[cpToken, rune] {
+	r := l.Next()
+	if r == 0 && l.Pos() >= l.Len() {
+		l.Emit(cpEOF)
+		return nil
+	}
+	l.Emit(cpWord)
+	return cpInitState
+}
+
+// TestTree_CheckpointRestore_RealLexer shows Restore rewinding the lexer itself, via
+// Cursor, past items already evicted from the lookahead buffer.
+func TestTree_CheckpointRestore_RealLexer(t *testing.T) {
+	input := []rune("abcd")
+	l := lex.New[cpToken, rune](cpInitState, input)
+	tree := New[cpToken, rune](l, nil, cpEOF, WithLookahead[cpToken, rune](2))
+
+	cp := tree.Checkpoint()
+
+	var got []rune
+	for i := 0; i < 4; i++ {
+		item := tree.Next()
+		if len(item.Value) > 0 {
+			got = append(got, item.Value[0])
+		}
+	}
+	if string(got) != "abcd" {
+		t.Fatalf("expected to consume abcd, got %q", string(got))
+	}
+
+	tree.Restore(cp)
+
+	if first := tree.Next(); len(first.Value) == 0 || first.Value[0] != 'a' {
+		t.Fatalf("expected Restore to replay from the start, got %v", first)
+	}
+}
+
+// TestTree_CheckpointRestore shows a ParseFn trying alternative A, restoring, and trying B.
+func TestTree_CheckpointRestore(t *testing.T) {
+	l := &stubLexer[stubToken, string]{
+		items: []lex.Item[stubToken, string]{
+			lex.NewItem(0, tokenWord, "b"),
+			lex.NewItem[stubToken, string](1, tokenEOF),
+		},
+	}
+
+	tree := New[stubToken, string](l, nil, tokenEOF)
+
+	cp := tree.Checkpoint()
+
+	// Alternative A: assume the next item is the root's own type; it is not, so restore.
+	if tree.Next().Type == tree.Root.Type {
+		t.Fatal("unexpected match for alternative A")
+	}
+	tree.Restore(cp)
+
+	// Alternative B: the restored Tree sees the same item again.
+	if got := tree.Next(); got.Type != tokenWord {
+		t.Fatalf("expected Restore to replay the first item, got %v", got.Type)
+	}
+}
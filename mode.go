@@ -0,0 +1,74 @@
+package parse
+
+import "io"
+
+// Mode is a bitmask of flags that configure how a Tree behaves as it is
+// built and walked. Flags are combined with a bitwise OR and supplied at
+// construction time through WithMode.
+type Mode uint
+
+const (
+	// StrictEOF causes Tree.Parse to record an error if the ParseFn chain
+	// terminates (returns nil) while the lexer still has non-EOF Items left
+	// to consume.
+	StrictEOF Mode = 1 << iota
+	// PreserveTrivia keeps whitespace and comment Items as Nodes in the
+	// Tree, through the Tree.Trivia helper, instead of letting a ParseFn
+	// silently discard them.
+	PreserveTrivia
+	// RecoverOnError converts any other panic raised from within a ParseFn
+	// into an error stored on the Tree, instead of crashing the caller.
+	// Panics raised via Tree.Errorf or Tree.Error are recovered by
+	// Tree.Parse whether or not this flag is set.
+	RecoverOnError
+	// NoAutoNest stops Tree.Node from automatically appending the created
+	// Node to the current Node's Edges. Callers relying on this flag are
+	// responsible for nesting Nodes explicitly.
+	NoAutoNest
+)
+
+// Has reports whether Mode `m` contains every flag set in `flags`.
+func (m Mode) Has(flags Mode) bool {
+	return m&flags == flags
+}
+
+// Option configures a Tree at construction time, through New or Run.
+type Option[C comparable, T any] func(*options[C, T])
+
+type options[C comparable, T any] struct {
+	mode      Mode
+	values    []T
+	trace     io.Writer
+	lookahead int
+}
+
+// WithMode sets the Mode bitmask that the Tree is built with.
+func WithMode[C comparable, T any](m Mode) Option[C, T] {
+	return func(o *options[C, T]) {
+		o.mode |= m
+	}
+}
+
+// WithValues sets the values that the Tree's Root Node is created with.
+func WithValues[C comparable, T any](values ...T) Option[C, T] {
+	return func(o *options[C, T]) {
+		o.values = values
+	}
+}
+
+// WithTrace configures the Tree to write an indented trace line to w for every ParseFn
+// invocation, describing the current node path, the peeked lex.Item, and the ParseFn's
+// identity. ParseFns can add their own lines to the same trace via Tree.Tracef.
+func WithTrace[C comparable, T any](w io.Writer) Option[C, T] {
+	return func(o *options[C, T]) {
+		o.trace = w
+	}
+}
+
+// WithLookahead sets the size of the Tree's lookahead buffer, used by Next, Peek, PeekN
+// and Backup. It defaults to 5 Items if unset or n is not positive.
+func WithLookahead[C comparable, T any](n int) Option[C, T] {
+	return func(o *options[C, T]) {
+		o.lookahead = n
+	}
+}
@@ -30,19 +30,38 @@ type Node[C comparable, T any] struct {
 //
 // Note: since creating a Node nests it under the previous Node, it is the responsibility
 // of the caller to move back to the parent if that is the intention
+//
+// If the Tree's Mode includes NoAutoNest, the new Node is not appended to the current
+// Node's Edges, and the caller is responsible for nesting it explicitly.
 func (t *Tree[C, T]) Node(item lex.Item[C, T]) *Node[C, T] {
 	n := &Node[C, T]{
 		Item:   item,
 		Parent: t.node,
 		Edges:  []*Node[C, T]{},
 	}
-	if t.node != nil {
+	if t.node != nil && !t.mode.Has(NoAutoNest) {
 		t.node.Edges = append(t.node.Edges, n)
 	}
 	t.node = n
+	if t.trace != nil {
+		t.traceDepth++
+	}
 	return n
 }
 
+// Trivia creates a Node for item only if the Tree's Mode includes
+// PreserveTrivia; otherwise it is a no-op and returns nil.
+//
+// ParseFns that would normally discard whitespace or comment Items should
+// route them through Trivia instead, so they are kept in the Tree when the
+// caller opts in via WithMode(PreserveTrivia).
+func (t *Tree[C, T]) Trivia(item lex.Item[C, T]) *Node[C, T] {
+	if !t.mode.Has(PreserveTrivia) {
+		return nil
+	}
+	return t.Node(item)
+}
+
 // Store places the current node in the input BackupSlot `slot`, in the parse.Tree
 //
 // If the current position is invalid, the root node (index zero) will be placed instead;
@@ -68,6 +87,7 @@ func (t *Tree[C, T]) Load(slot BackupSlot) *Node[C, T] {
 func (t *Tree[C, T]) Jump(slot BackupSlot) (bool, error) {
 	t.node = t.backup[slot]
 	delete(t.backup, slot)
+	t.traceUnnest()
 	return true, nil
 }
 
@@ -77,9 +97,18 @@ func (t *Tree[C, T]) Set(n *Node[C, T]) error {
 		return ErrNotFound
 	}
 	t.node = n
+	t.traceUnnest()
 	return nil
 }
 
+// traceUnnest decrements the trace indent depth when a trace writer is configured, mirroring
+// a ParseFn moving the current node back up to a parent.
+func (t *Tree[C, T]) traceUnnest() {
+	if t.trace != nil && t.traceDepth > 0 {
+		t.traceDepth--
+	}
+}
+
 // List returns all top-level nodes, under the Tree's Root
 func (t *Tree[C, T]) List() []*Node[C, T] {
 	return t.Root.Edges
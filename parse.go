@@ -8,6 +8,9 @@ import "github.com/zalgonoise/lex"
 // The ParseFn will return another ParseFn that will keep processing the items; which
 // could be done in a number of ways (switch statements, helper functions, etc). When
 // `nil` is returned, the parser will stop processing lex items
+//
+// A ParseFn can abort the parse at any point by calling Tree.Errorf or Tree.Error; the
+// chain stops immediately and Tree.Parse returns the resulting *Error[C, T]
 type ParseFn[C comparable, T any] func(t *Tree[C, T]) ParseFn[C, T]
 
 // ProcessFn is a function that can be executed after parsing all the items, and will
@@ -22,17 +25,30 @@ type NodeFn[C comparable, T any, R any] func(n *Node[C, T]) (R, error)
 // Run encapsulates the lexer and parser runtime into a single one-shot action
 //
 // The caller must supply the input data []T `input`, a lex.StateFn to kick-off the lexer,
-// a ParseFn to kick-off the parser, and a ProcessFn to convert the parse.Tree into the
-// desired return type (or an error)
+// a ParseFn to kick-off the parser, the token type `typ` the lexer emits to signal EOF
+// (also used, as in New, as the Tree's Root type), and a ProcessFn to convert the
+// parse.Tree into the desired return type (or an error). Tree behavior can be configured
+// through Option values such as WithMode, e.g.
+// `parse.Run(input, initState, initParse, eof, process, parse.WithMode(...))`.
+//
+// typ matters beyond labelling the Root: Tree.Parse's StrictEOF check compares it against
+// the last peeked Item's Type to decide whether the lexer was actually exhausted, so it
+// must be the real EOF token, not a placeholder.
+//
+// If Tree.Parse returns an error, it is returned as-is and processFn is not invoked.
 func Run[C comparable, T any, R any](
 	input []T,
 	initStateFn lex.StateFn[C, T],
 	initParseFn ParseFn[C, T],
+	typ C,
 	processFn ProcessFn[C, T, R],
+	opts ...Option[C, T],
 ) (R, error) {
-	var rootEOF C
 	l := lex.New(initStateFn, input)
-	t := New(l, initParseFn, rootEOF)
-	t.Parse()
+	t := New(l, initParseFn, typ, opts...)
+	if err := t.Parse(); err != nil {
+		var zero R
+		return zero, err
+	}
 	return processFn(t)
 }
@@ -0,0 +1,98 @@
+package parse
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/zalgonoise/lex"
+)
+
+// noopProcess is a ProcessFn that returns the Tree unchanged, for Run tests that only
+// care about the error Tree.Parse produces.
+func noopProcess(tr *Tree[cpToken, rune]) (*Tree[cpToken, rune], error) {
+	return tr, nil
+}
+
+func TestRun_StrictEOF_DetectsPendingInput(t *testing.T) {
+	stopEarly := func(tr *Tree[cpToken, rune]) ParseFn[cpToken, rune] {
+		tr.Next()
+		return nil
+	}
+
+	_, err := Run[cpToken, rune, *Tree[cpToken, rune]](
+		[]rune("ab"),
+		cpInitState,
+		stopEarly,
+		cpEOF,
+		noopProcess,
+		WithMode[cpToken, rune](StrictEOF),
+	)
+	if !errors.Is(err, ErrNonEOFRemaining) {
+		t.Fatalf("expected ErrNonEOFRemaining, got %v", err)
+	}
+}
+
+func TestRun_StrictEOF_NoErrorWhenFullyConsumed(t *testing.T) {
+	var consumeAll ParseFn[cpToken, rune]
+	consumeAll = func(tr *Tree[cpToken, rune]) ParseFn[cpToken, rune] {
+		if tr.Peek().Type == cpEOF {
+			return nil
+		}
+		tr.Next()
+		return consumeAll
+	}
+
+	_, err := Run[cpToken, rune, *Tree[cpToken, rune]](
+		[]rune("ab"),
+		cpInitState,
+		consumeAll,
+		cpEOF,
+		noopProcess,
+		WithMode[cpToken, rune](StrictEOF),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestTree_Trivia_DiscardedWithoutPreserveTrivia(t *testing.T) {
+	l := &stubLexer[stubToken, string]{
+		items: []lex.Item[stubToken, string]{lex.NewItem[stubToken, string](0, tokenEOF)},
+	}
+	tree := New[stubToken, string](l, nil, tokenEOF)
+
+	if n := tree.Trivia(lex.NewItem(0, tokenWord, " ")); n != nil {
+		t.Fatal("expected Trivia to be a no-op without PreserveTrivia")
+	}
+	if len(tree.Root.Edges) != 0 {
+		t.Fatalf("expected no Edges, got %d", len(tree.Root.Edges))
+	}
+}
+
+func TestTree_Trivia_KeptWithPreserveTrivia(t *testing.T) {
+	l := &stubLexer[stubToken, string]{
+		items: []lex.Item[stubToken, string]{lex.NewItem[stubToken, string](0, tokenEOF)},
+	}
+	tree := New[stubToken, string](l, nil, tokenEOF, WithMode[stubToken, string](PreserveTrivia))
+
+	if n := tree.Trivia(lex.NewItem(0, tokenWord, " ")); n == nil {
+		t.Fatal("expected Trivia to create a Node with PreserveTrivia")
+	}
+	if len(tree.Root.Edges) != 1 {
+		t.Fatalf("expected 1 Edge, got %d", len(tree.Root.Edges))
+	}
+}
+
+func TestTree_NoAutoNest(t *testing.T) {
+	l := &stubLexer[stubToken, string]{
+		items: []lex.Item[stubToken, string]{lex.NewItem[stubToken, string](0, tokenEOF)},
+	}
+	tree := New[stubToken, string](l, nil, tokenEOF, WithMode[stubToken, string](NoAutoNest))
+	root := tree.Root
+
+	tree.Node(lex.NewItem(0, tokenWord, "a"))
+
+	if len(root.Edges) != 0 {
+		t.Fatalf("expected NoAutoNest to skip auto-nesting, got %d Edges", len(root.Edges))
+	}
+}
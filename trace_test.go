@@ -0,0 +1,37 @@
+package parse
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/zalgonoise/lex"
+)
+
+func TestTree_WithTrace(t *testing.T) {
+	var buf bytes.Buffer
+
+	step := func(tr *Tree[stubToken, string]) ParseFn[stubToken, string] {
+		tr.Next()
+		return nil
+	}
+
+	l := &stubLexer[stubToken, string]{
+		items: []lex.Item[stubToken, string]{
+			lex.NewItem(0, tokenWord, "hello"),
+			lex.NewItem[stubToken, string](1, tokenEOF),
+		},
+	}
+
+	tree := New[stubToken, string](l, step, tokenEOF, WithTrace[stubToken, string](&buf))
+	if err := tree.Parse(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if buf.Len() == 0 {
+		t.Fatal("expected trace output, got none")
+	}
+	if !strings.Contains(buf.String(), "TestTree_WithTrace") {
+		t.Errorf("expected trace to reference the ParseFn, got %q", buf.String())
+	}
+}
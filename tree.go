@@ -2,6 +2,11 @@ package parse
 
 import (
 	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"runtime"
+	"strings"
 
 	"github.com/zalgonoise/lex"
 )
@@ -17,6 +22,9 @@ var (
 	ErrNotFound = errors.New("node was not found")
 	// ErrInvalidID is a preset error for cyclical edges in the graph
 	ErrCyclicalEdge = errors.New("cyclical edges are not allowed")
+	// ErrNonEOFRemaining is a preset error for when the StrictEOF Mode is set
+	// and the ParseFn chain stops before the lexer reaches its EOF Item
+	ErrNonEOFRemaining = errors.New("parser stopped before reaching EOF")
 )
 
 // BackupSlot is a (weightless) enum type to create defined containers
@@ -51,71 +59,225 @@ type Tree[C comparable, T any] struct {
 	Root *Node[C, T]
 
 	node    *Node[C, T]
-	items   []lex.Item[C, T]
-	lex     lex.Lexer[C, T]
-	peek    int
+	lex     lex.Emitter[C, T]
+	last    lex.Item[C, T]
 	backup  map[BackupSlot]*Node[C, T]
 	parseFn ParseFn[C, T]
+	mode    Mode
+	err     error
+
+	// ring is a fixed-size lookahead buffer of Items pulled ahead of consumption from the
+	// lexer (or pushed back via Backup). head is the index of the next Item Next() will
+	// return, and count is how many of ring's slots currently hold a valid, unconsumed
+	// Item.
+	ring  []lex.Item[C, T]
+	head  int
+	count int
+
+	trace      io.Writer
+	traceDepth int
 }
 
-// New creates a parse.Tree with the input lex.Lexer `l` and ParseFn `initParse`,
-// initialized with a root node with type T `typ` and values V `values`, on position `-1`.
+// New creates a parse.Tree with the input lex.Emitter `l` and ParseFn `initParse`,
+// initialized with a root node with type T `typ`, on position `-1`.
+//
+// l only needs to satisfy lex.Emitter, since NextItem is the only method the Tree
+// requires of it; a full lex.Lexer (such as the one returned by lex.New) satisfies this
+// too. If l also implements Cursor, Checkpoint and Restore use it to rewind the lexer.
+//
+// Behavior is configured through Option values such as WithMode and
+// WithValues, e.g. `parse.New(l, initParse, eof, parse.WithMode(...))`.
 func New[C comparable, T any](
-	l lex.Lexer[C, T],
+	l lex.Emitter[C, T],
 	initParse ParseFn[C, T],
 	typ C,
-	values ...T,
+	opts ...Option[C, T],
 ) *Tree[C, T] {
+	o := &options[C, T]{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	lookahead := o.lookahead
+	if lookahead <= 0 {
+		lookahead = maxBackup
+	}
+
 	t := &Tree[C, T]{
-		items:   make([]lex.Item[C, T], maxBackup),
+		ring:    make([]lex.Item[C, T], lookahead),
 		lex:     l,
-		peek:    0,
 		backup:  map[BackupSlot]*Node[C, T]{},
 		parseFn: initParse,
+		mode:    o.mode,
+		trace:   o.trace,
 	}
-	t.Root = t.Node(lex.NewItem(-1, typ, values...))
+	t.Root = t.Node(lex.NewItem(-1, typ, o.values...))
 	t.node = t.Root
 	return t
 }
 
+// Mode returns the Mode bitmask that the Tree was configured with, so a
+// ParseFn can branch on the flags that are set.
+func (t *Tree[C, T]) Mode() Mode {
+	return t.mode
+}
+
+// Err returns the error recorded while parsing -- for instance a StrictEOF
+// violation -- or nil if none occurred.
+func (t *Tree[C, T]) Err() error {
+	return t.err
+}
+
 // Next consumes and returns the next Item from the lexer
 func (t *Tree[C, T]) Next() lex.Item[C, T] {
-	if t.peek > 0 {
-		t.peek--
-	} else {
-		t.items[0] = t.lex.NextItem()
-	}
-	return t.items[t.peek]
+	t.fill(1)
+	item := t.ring[t.head]
+	t.head = (t.head + 1) % len(t.ring)
+	t.count--
+	t.last = item
+	return item
 }
 
 // Peek returns but does not consume the next Item from the lexer
 func (t *Tree[C, T]) Peek() lex.Item[C, T] {
-	if t.peek > 0 {
-		return t.items[t.peek-1]
+	return t.PeekN(1)
+}
+
+// PeekN returns, but does not consume, the nth Item ahead of the current position (n == 1
+// behaves like Peek). Items are lazily pulled from the lexer and cached in the Tree's
+// lookahead buffer, whose size is set at construction via WithLookahead. If n exceeds that
+// size, the furthest buffered Item is returned instead.
+func (t *Tree[C, T]) PeekN(n int) lex.Item[C, T] {
+	if n < 1 {
+		n = 1
 	}
-	t.peek = 1
+	if n > len(t.ring) {
+		n = len(t.ring)
+	}
+	t.fill(n)
+	idx := (t.head + n - 1) % len(t.ring)
+	return t.ring[idx]
+}
 
-	t.items[0] = t.lex.NextItem()
-	return t.items[0]
+// fill ensures at least n Items are buffered ahead of the current position, pulling from
+// the lexer as needed, up to the lookahead buffer's capacity.
+func (t *Tree[C, T]) fill(n int) {
+	for t.count < n && t.count < len(t.ring) {
+		idx := (t.head + t.count) % len(t.ring)
+		t.ring[idx] = t.lex.NextItem()
+		t.count++
+	}
 }
 
-// Backup backs the stream up `n` Items
+// Backup backs the stream up with `items`
 //
-// The zeroth Item is already there. Order must be most recent -> oldest
+// The zeroth Item is the one that will be returned by the next call to Next or Peek.
+// Order must be most recent -> oldest. If items does not fit in the lookahead buffer's
+// remaining capacity, the oldest excess items are dropped -- items[0] and its most recent
+// neighbors always survive.
 func (t *Tree[C, T]) Backup(items ...lex.Item[C, T]) {
-	for idx, item := range items {
-		if idx+1 >= maxBackup {
-			break
-		}
-		t.items[idx+1] = item
+	available := len(t.ring) - t.count
+	if len(items) > available {
+		items = items[:available]
+	}
+	for i := len(items) - 1; i >= 0; i-- {
+		t.head = (t.head - 1 + len(t.ring)) % len(t.ring)
+		t.ring[t.head] = items[i]
+		t.count++
 	}
-	t.peek = len(items)
 }
 
 // Parse iterates through the incoming lex Items, by calling its `ParseFn`s, until all tokens
-// are consumed and parsed into the Tree
-func (t *Tree[C, T]) Parse() {
+// are consumed and parsed into the Tree, or a ParseFn aborts the parse via Tree.Errorf or
+// Tree.Error.
+//
+// A panic raised via Tree.Errorf or Tree.Error is always recovered and returned as an
+// *Error[C, T]. Any other panic is only recovered (and likewise returned as an
+// *Error[C, T]) if the Tree's Mode includes RecoverOnError; otherwise it is re-raised to
+// the caller. If the Tree's Mode includes StrictEOF, an error is also returned when the
+// ParseFn chain ends before the lexer's next Item matches the Tree's root type.
+func (t *Tree[C, T]) Parse() (err error) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+
+		if parseErr, ok := r.(*Error[C, T]); ok {
+			t.err = parseErr
+			err = parseErr
+			return
+		}
+
+		if !t.mode.Has(RecoverOnError) {
+			panic(r)
+		}
+
+		parseErr := &Error[C, T]{Item: t.last, Cause: fmt.Errorf("%v", r)}
+		t.err = parseErr
+		err = parseErr
+	}()
+
 	for t.parseFn != nil {
+		if t.trace != nil {
+			t.traceStep(t.parseFn)
+		}
 		t.parseFn = t.parseFn(t)
 	}
+
+	if t.mode.Has(StrictEOF) {
+		if next := t.Peek(); next.Type != t.Root.Type {
+			t.err = fmt.Errorf("%w: %v", ErrNonEOFRemaining, next)
+		}
+	}
+	return t.err
+}
+
+// Errorf formats according to a format specifier and aborts the current ParseFn chain by
+// panicking with the resulting error. Parse recovers this panic and returns it as an
+// *Error[C, T], with the last consumed Item attached for position context.
+func (t *Tree[C, T]) Errorf(format string, args ...any) {
+	t.Error(fmt.Errorf(format, args...))
+}
+
+// Error aborts the current ParseFn chain by panicking with err. Parse recovers this panic
+// and returns it as an *Error[C, T], with the last consumed Item attached for position
+// context.
+func (t *Tree[C, T]) Error(err error) {
+	panic(&Error[C, T]{
+		Item:  t.last,
+		Cause: err,
+	})
+}
+
+// Tracef writes an indented line to the Tree's trace writer, configured via WithTrace, or
+// is a no-op if none was set. The indent is one level per Tree.Node call (the Root's
+// creation included), less one level per Tree.Set or Tree.Jump call, so custom ParseFns
+// can inject their own diagnostic lines alongside the ones Parse emits for each ParseFn
+// invocation.
+func (t *Tree[C, T]) Tracef(format string, args ...any) {
+	if t.trace == nil {
+		return
+	}
+	fmt.Fprintf(t.trace, "%s%s\n", strings.Repeat("  ", t.traceDepth), fmt.Sprintf(format, args...))
+}
+
+// traceStep logs the identity of the ParseFn about to run, the current node path, and the
+// peeked Item, ahead of invoking it.
+func (t *Tree[C, T]) traceStep(fn ParseFn[C, T]) {
+	name := runtime.FuncForPC(reflect.ValueOf(fn).Pointer()).Name()
+	t.Tracef("%s: path=%s peek=%v", name, t.nodePath(), t.Peek())
+}
+
+// nodePath joins the Types of the current node and its ancestors, from Root down to the
+// current position, separated by "/".
+func (t *Tree[C, T]) nodePath() string {
+	var parts []string
+	for n := t.node; n != nil; n = n.Parent {
+		parts = append(parts, fmt.Sprint(n.Type))
+	}
+	for i, j := 0, len(parts)-1; i < j; i, j = i+1, j-1 {
+		parts[i], parts[j] = parts[j], parts[i]
+	}
+	return strings.Join(parts, "/")
 }
@@ -0,0 +1,98 @@
+package parse
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/zalgonoise/lex"
+)
+
+type stubLexer[C comparable, T any] struct {
+	items []lex.Item[C, T]
+	idx   int
+}
+
+func (s *stubLexer[C, T]) NextItem() lex.Item[C, T] {
+	if s.idx >= len(s.items) {
+		return s.items[len(s.items)-1]
+	}
+	item := s.items[s.idx]
+	s.idx++
+	return item
+}
+
+// Pos and Idx make stubLexer satisfy Cursor, so tests can exercise Checkpoint/Restore's
+// true-rewind path the same way a real lex.Lexer would.
+func (s *stubLexer[C, T]) Pos() int {
+	return s.idx
+}
+
+func (s *stubLexer[C, T]) Idx(idx int) T {
+	if idx < 0 {
+		idx = 0
+	}
+	if idx > len(s.items) {
+		idx = len(s.items)
+	}
+	s.idx = idx
+	var zero T
+	return zero
+}
+
+type stubToken int
+
+const (
+	tokenWord stubToken = iota
+	tokenEOF
+)
+
+func TestTree_Parse_RecoversErrorf(t *testing.T) {
+	wantErr := errors.New("unexpected token")
+
+	failing := func(tr *Tree[stubToken, string]) ParseFn[stubToken, string] {
+		tr.Errorf("parsing failed: %w", wantErr)
+		return nil
+	}
+
+	l := &stubLexer[stubToken, string]{
+		items: []lex.Item[stubToken, string]{
+			lex.NewItem(0, tokenWord, "hello"),
+			lex.NewItem[stubToken, string](1, tokenEOF),
+		},
+	}
+
+	tree := New[stubToken, string](l, failing, tokenEOF)
+
+	err := tree.Parse()
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+
+	var parseErr *Error[stubToken, string]
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("expected *Error, got %T", err)
+	}
+
+	if !errors.Is(parseErr, wantErr) {
+		t.Errorf("expected cause %v, got %v", wantErr, parseErr.Cause)
+	}
+}
+
+func TestTree_Parse_RecoverOnError(t *testing.T) {
+	panics := func(tr *Tree[stubToken, string]) ParseFn[stubToken, string] {
+		panic("boom")
+	}
+
+	l := &stubLexer[stubToken, string]{
+		items: []lex.Item[stubToken, string]{
+			lex.NewItem[stubToken, string](0, tokenEOF),
+		},
+	}
+
+	tree := New[stubToken, string](l, panics, tokenEOF, WithMode[stubToken, string](RecoverOnError))
+
+	err := tree.Parse()
+	if err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+}
@@ -0,0 +1,110 @@
+package parse
+
+// WalkAction controls how Tree.Walk / Node.Walk continues after a WalkFn is invoked.
+type WalkAction int
+
+const (
+	// Continue proceeds with the walk: into the current Node's Edges in pre-order, or to
+	// the next Node in post-order.
+	Continue WalkAction = iota
+	// SkipEdges skips the current Node's Edges without stopping the walk. It only applies
+	// to pre-order walks, since a post-order walk has already visited a Node's Edges by
+	// the time its WalkFn runs.
+	SkipEdges
+	// Stop ends the walk immediately.
+	Stop
+)
+
+// WalkFn is invoked for each Node visited by Tree.Walk / Node.Walk, receiving the Node and
+// its depth relative to the Node the walk started from (0 for the starting Node itself).
+type WalkFn[C comparable, T any] func(n *Node[C, T], depth int) (WalkAction, error)
+
+// Walk traverses the Tree in pre-order (a Node before its Edges), starting at the Root. It
+// is equivalent to t.Root.Walk(fn).
+func (t *Tree[C, T]) Walk(fn WalkFn[C, T]) error {
+	return t.Root.Walk(fn)
+}
+
+// Walk traverses the Node and its Edges in pre-order (a Node before its Edges), calling fn
+// for every Node visited.
+func (n *Node[C, T]) Walk(fn WalkFn[C, T]) error {
+	_, err := walkPreOrder(n, 0, fn)
+	return err
+}
+
+func walkPreOrder[C comparable, T any](n *Node[C, T], depth int, fn WalkFn[C, T]) (WalkAction, error) {
+	action, err := fn(n, depth)
+	if err != nil {
+		return Stop, err
+	}
+	if action == Stop {
+		return Stop, nil
+	}
+	if action == SkipEdges {
+		return Continue, nil
+	}
+
+	for _, edge := range n.Edges {
+		action, err := walkPreOrder(edge, depth+1, fn)
+		if err != nil {
+			return Stop, err
+		}
+		if action == Stop {
+			return Stop, nil
+		}
+	}
+	return Continue, nil
+}
+
+// WalkPostOrder traverses the Tree in post-order (a Node's Edges before the Node itself),
+// starting at the Root. It is equivalent to t.Root.WalkPostOrder(fn).
+func (t *Tree[C, T]) WalkPostOrder(fn WalkFn[C, T]) error {
+	return t.Root.WalkPostOrder(fn)
+}
+
+// WalkPostOrder traverses the Node and its Edges in post-order (a Node's Edges before the
+// Node itself), calling fn for every Node visited.
+func (n *Node[C, T]) WalkPostOrder(fn WalkFn[C, T]) error {
+	_, err := walkPostOrder(n, 0, fn)
+	return err
+}
+
+func walkPostOrder[C comparable, T any](n *Node[C, T], depth int, fn WalkFn[C, T]) (WalkAction, error) {
+	for _, edge := range n.Edges {
+		action, err := walkPostOrder(edge, depth+1, fn)
+		if err != nil {
+			return Stop, err
+		}
+		if action == Stop {
+			return Stop, nil
+		}
+	}
+
+	action, err := fn(n, depth)
+	if err != nil {
+		return Stop, err
+	}
+	if action == Stop {
+		return Stop, nil
+	}
+	return Continue, nil
+}
+
+// Find returns every Node in the Tree (including the Root) for which pred returns true, in
+// pre-order.
+func (t *Tree[C, T]) Find(pred func(n *Node[C, T]) bool) []*Node[C, T] {
+	return t.Root.Find(pred)
+}
+
+// Find returns every Node in the subtree rooted at n (including n itself) for which pred
+// returns true, in pre-order.
+func (n *Node[C, T]) Find(pred func(n *Node[C, T]) bool) []*Node[C, T] {
+	var found []*Node[C, T]
+	_ = n.Walk(func(cur *Node[C, T], _ int) (WalkAction, error) {
+		if pred(cur) {
+			found = append(found, cur)
+		}
+		return Continue, nil
+	})
+	return found
+}
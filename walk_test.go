@@ -0,0 +1,157 @@
+package parse
+
+import (
+	"testing"
+
+	"github.com/zalgonoise/lex"
+)
+
+func buildTestTree() *Tree[stubToken, string] {
+	l := &stubLexer[stubToken, string]{
+		items: []lex.Item[stubToken, string]{lex.NewItem[stubToken, string](0, tokenEOF)},
+	}
+	tree := New[stubToken, string](l, nil, tokenEOF)
+	root := tree.Root
+
+	tree.Node(lex.NewItem(0, tokenWord, "a"))
+	tree.Node(lex.NewItem(2, tokenWord, "c")) // nested under "a"
+
+	if err := tree.Set(root); err != nil {
+		panic(err)
+	}
+	tree.Node(lex.NewItem(1, tokenWordB, "b")) // sibling of "a", under root
+	return tree
+}
+
+func TestTree_WalkPreOrder(t *testing.T) {
+	tree := buildTestTree()
+
+	var visited []stubToken
+	err := tree.Walk(func(n *Node[stubToken, string], _ int) (WalkAction, error) {
+		visited = append(visited, n.Type)
+		return Continue, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []stubToken{tokenEOF, tokenWord, tokenWord, tokenWordB}
+	if len(visited) != len(want) {
+		t.Fatalf("expected %d nodes visited, got %d (%v)", len(want), len(visited), visited)
+	}
+	for i, tok := range want {
+		if visited[i] != tok {
+			t.Errorf("visit order mismatch at %d: want %v, got %v", i, tok, visited[i])
+		}
+	}
+}
+
+func TestTree_WalkPostOrder(t *testing.T) {
+	tree := buildTestTree()
+
+	var visited []string
+	err := tree.WalkPostOrder(func(n *Node[stubToken, string], _ int) (WalkAction, error) {
+		if len(n.Value) > 0 {
+			visited = append(visited, n.Value[0])
+		} else {
+			visited = append(visited, "")
+		}
+		return Continue, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"c", "a", "b", ""}
+	if len(visited) != len(want) {
+		t.Fatalf("expected %d nodes visited, got %d (%v)", len(want), len(visited), visited)
+	}
+	for i, v := range want {
+		if visited[i] != v {
+			t.Errorf("visit order mismatch at %d: want %q, got %q", i, v, visited[i])
+		}
+	}
+}
+
+func TestTree_Walk_SkipEdges(t *testing.T) {
+	tree := buildTestTree()
+
+	var visited []string
+	err := tree.Walk(func(n *Node[stubToken, string], _ int) (WalkAction, error) {
+		if len(n.Value) > 0 {
+			visited = append(visited, n.Value[0])
+		}
+		if len(n.Value) > 0 && n.Value[0] == "a" {
+			return SkipEdges, nil
+		}
+		return Continue, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"a", "b"}
+	if len(visited) != len(want) {
+		t.Fatalf("expected %d nodes visited (skipping \"a\"'s edges), got %d (%v)", len(want), len(visited), visited)
+	}
+	for i, v := range want {
+		if visited[i] != v {
+			t.Errorf("visit order mismatch at %d: want %q, got %q", i, v, visited[i])
+		}
+	}
+}
+
+func TestTree_Walk_Stop(t *testing.T) {
+	tree := buildTestTree()
+
+	var visited []string
+	err := tree.Walk(func(n *Node[stubToken, string], _ int) (WalkAction, error) {
+		if len(n.Value) > 0 {
+			visited = append(visited, n.Value[0])
+			if n.Value[0] == "a" {
+				return Stop, nil
+			}
+		}
+		return Continue, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{"a"}
+	if len(visited) != len(want) {
+		t.Fatalf("expected the walk to stop right after \"a\", got %v", visited)
+	}
+}
+
+func TestTree_Find(t *testing.T) {
+	tree := buildTestTree()
+
+	found := tree.Find(func(n *Node[stubToken, string]) bool {
+		return n.Type == tokenWordB
+	})
+	if len(found) != 1 {
+		t.Fatalf("expected exactly one match, got %d", len(found))
+	}
+}
+
+func TestTree_Copy(t *testing.T) {
+	tree := buildTestTree()
+	cp := tree.Copy()
+
+	if cp == tree {
+		t.Fatal("expected Copy to return a different Tree")
+	}
+	if cp.Root == tree.Root {
+		t.Fatal("expected Copy to deep-copy the Root node")
+	}
+	if len(cp.Root.Edges) != len(tree.Root.Edges) {
+		t.Fatalf("expected %d edges, got %d", len(tree.Root.Edges), len(cp.Root.Edges))
+	}
+	if cp.Root.Edges[0] == tree.Root.Edges[0] {
+		t.Fatal("expected Copy's edges to be distinct from the original's")
+	}
+	if cp.Root.Edges[0].Parent != cp.Root {
+		t.Fatal("expected Copy's edges to be re-parented to the copy")
+	}
+}